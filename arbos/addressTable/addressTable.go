@@ -82,6 +82,9 @@ func (atab *AddressTable) LookupIndex(index uint64) (common.Address, bool, error
 	return common.BytesToAddress(value.Bytes()), true, err
 }
 
+// Compress encodes an address as the RLP encoding of its table index if it's registered,
+// which takes at most 9 bytes, and otherwise as the RLP encoding of its 20 raw bytes, which takes 21.
+// The two cases are distinguished by the RLP string length, so decompression is unambiguous.
 func (atab *AddressTable) Compress(addr common.Address) ([]byte, error) {
 	index, exists, err := atab.Lookup(addr)
 	if exists || err != nil {
@@ -95,6 +98,7 @@ func (atab *AddressTable) Compress(addr common.Address) ([]byte, error) {
 	}
 }
 
+// Decompress reads one compressed address from the front of buf, returning it along with the number of bytes consumed.
 func (atab *AddressTable) Decompress(buf []byte) (common.Address, uint64, error) {
 	rd := bytes.NewReader(buf)
 	decoder := rlp.NewStream(rd, 21)
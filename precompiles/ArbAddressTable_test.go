@@ -9,11 +9,14 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/offchainlabs/nitro/arbos"
 	"github.com/offchainlabs/nitro/arbos/arbosState"
+	"github.com/offchainlabs/nitro/arbos/burn"
 	"github.com/offchainlabs/nitro/util/testhelpers"
 )
 
@@ -169,6 +172,25 @@ func newMockEVMForTestingWithVersion(version *uint64) *vm.EVM {
 	return evm
 }
 
+// newMockEVMForTestingAtArbosVersion makes a mock EVM whose ArbOS state is initialized at the given version,
+// whereas newMockEVMForTestingWithVersion only changes the chain config
+func newMockEVMForTestingAtArbosVersion(t *testing.T, version uint64) *vm.EVM {
+	chainConfig := params.ArbitrumDevTestChainConfig()
+	chainConfig.ArbitrumChainParams.InitialArbOSVersion = version
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	Require(t, err)
+	_, err = arbosState.InitializeArbosState(statedb, burn.NewSystemBurner(nil, false), chainConfig)
+	Require(t, err)
+	context := vm.BlockContext{
+		BlockNumber: big.NewInt(0),
+		GasLimit:    ^uint64(0),
+		Time:        0,
+	}
+	evm := vm.NewEVM(context, vm.TxContext{}, statedb, chainConfig, vm.Config{})
+	evm.ProcessingHook = &arbos.TxProcessor{}
+	return evm
+}
+
 func Require(t *testing.T, err error, printables ...interface{}) {
 	t.Helper()
 	testhelpers.RequireImpl(t, err, printables...)
@@ -178,3 +200,80 @@ func Fail(t *testing.T, printables ...interface{}) {
 	t.Helper()
 	testhelpers.FailImpl(t, printables...)
 }
+
+func TestAddressTableRegisterTwice(t *testing.T) {
+	evm := newMockEVMForTesting()
+	atab := ArbAddressTable{}
+	context := testContext(common.Address{}, evm)
+
+	addr1 := common.BytesToAddress(crypto.Keccak256([]byte{1})[:20])
+	addr2 := common.BytesToAddress(crypto.Keccak256([]byte{2})[:20])
+
+	// indices are assigned in registration order
+	slot, err := atab.Register(context, evm, addr1)
+	Require(t, err)
+	if slot.Cmp(big.NewInt(0)) != 0 {
+		Fail(t, "first address registered at", slot)
+	}
+	slot, err = atab.Register(context, evm, addr2)
+	Require(t, err)
+	if slot.Cmp(big.NewInt(1)) != 0 {
+		Fail(t, "second address registered at", slot)
+	}
+
+	// registering again returns the existing index without growing the table
+	slot, err = atab.Register(context, evm, addr1)
+	Require(t, err)
+	if slot.Cmp(big.NewInt(0)) != 0 {
+		Fail(t, "re-registration moved the address to", slot)
+	}
+	size, err := atab.Size(context, evm)
+	Require(t, err)
+	if size.Cmp(big.NewInt(2)) != 0 {
+		Fail(t, "re-registration changed the size to", size)
+	}
+
+	index, err := atab.Lookup(context, evm, addr2)
+	Require(t, err)
+	if index.Cmp(big.NewInt(1)) != 0 {
+		Fail(t, "lookup returned", index)
+	}
+	if _, err := atab.Lookup(context, evm, common.HexToAddress("0x0123")); err == nil {
+		Fail(t, "looked up an unregistered address")
+	}
+}
+
+func TestAddressTableExistsAndLookupIndex(t *testing.T) {
+	evm := newMockEVMForTesting()
+	atab := ArbAddressTable{}
+	context := testContext(common.Address{}, evm)
+
+	registered := common.BytesToAddress(crypto.Keccak256([]byte{1})[:20])
+	unregistered := common.BytesToAddress(crypto.Keccak256([]byte{2})[:20])
+	_, err := atab.Register(context, evm, registered)
+	Require(t, err)
+
+	exists, err := atab.AddressExists(context, evm, registered)
+	Require(t, err)
+	if !exists {
+		Fail(t, "registered address doesn't exist")
+	}
+	exists, err = atab.AddressExists(context, evm, unregistered)
+	Require(t, err)
+	if exists {
+		Fail(t, "unregistered address exists")
+	}
+
+	result, err := atab.LookupIndex(context, evm, big.NewInt(0))
+	Require(t, err)
+	if result != registered {
+		Fail(t, "index 0 holds", result, "instead of", registered)
+	}
+
+	tooLarge := new(big.Int).Lsh(common.Big1, 64)
+	for _, index := range []*big.Int{big.NewInt(1), big.NewInt(1000), tooLarge} {
+		if _, err := atab.LookupIndex(context, evm, index); err == nil {
+			Fail(t, "looked up out of range index", index)
+		}
+	}
+}
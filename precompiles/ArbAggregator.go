@@ -4,7 +4,6 @@
 package precompiles
 
 import (
-	"errors"
 	"math/big"
 
 	"github.com/offchainlabs/nitro/arbos/l1pricing"
@@ -17,8 +16,6 @@ type ArbAggregator struct {
 	Address addr // 0x6d
 }
 
-var ErrNotOwner = errors.New("must be called by chain owner")
-
 // GetPreferredAggregator returns the preferred aggregator address.
 // Deprecated: Do not use this method.
 func (con ArbAggregator) GetPreferredAggregator(c ctx, evm mech, address addr) (prefAgg addr, isDefault bool, err error) {
@@ -37,13 +34,9 @@ func (con ArbAggregator) GetBatchPosters(c ctx, evm mech) ([]addr, error) {
 }
 
 func (con ArbAggregator) AddBatchPoster(c ctx, evm mech, newBatchPoster addr) error {
-	isOwner, err := c.State.ChainOwners().IsMember(c.caller)
-	if err != nil {
+	if err := requireOwner(c); err != nil {
 		return err
 	}
-	if !isOwner {
-		return ErrNotOwner
-	}
 	batchPosterTable := c.State.L1PricingState().BatchPosterTable()
 	isBatchPoster, err := batchPosterTable.ContainsPoster(newBatchPoster)
 	if err != nil {
@@ -78,13 +71,9 @@ func (con ArbAggregator) SetFeeCollector(c ctx, evm mech, batchPoster addr, newF
 		return err
 	}
 	if c.caller != batchPoster && c.caller != oldFeeCollector {
-		isOwner, err := c.State.ChainOwners().IsMember(c.caller)
-		if err != nil {
+		if err := requireOwner(c); err != nil {
 			return err
 		}
-		if !isOwner {
-			return errors.New("only a batch poster (or its fee collector / chain owner) may change its fee collector")
-		}
 	}
 	return posterInfo.SetPayTo(newFeeCollector)
 }
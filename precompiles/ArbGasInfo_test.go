@@ -0,0 +1,131 @@
+// Copyright 2021-2023, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package precompiles
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/offchainlabs/nitro/arbos/storage"
+)
+
+func TestGetPricesInWei(t *testing.T) {
+	evm := newMockEVMForTesting()
+	callCtx := testContext(common.Address{}, evm)
+	gasInfo := &ArbGasInfo{}
+
+	l1PricePerUnit := big.NewInt(30)
+	minBaseFee := big.NewInt(100000000)
+	evm.Context.BaseFee = big.NewInt(250000000)
+	Require(t, callCtx.State.L1PricingState().SetPricePerUnit(l1PricePerUnit))
+	Require(t, callCtx.State.L2PricingState().SetMinBaseFeeWei(minBaseFee))
+
+	perL2Tx, perL1CalldataByte, perStorage, perArbGasBase, perArbGasCongestion, perArbGasTotal, err :=
+		gasInfo.GetPricesInWei(callCtx, evm)
+	Require(t, err)
+
+	expectedPerL1CalldataByte := new(big.Int).Mul(l1PricePerUnit, big.NewInt(int64(params.TxDataNonZeroGasEIP2028)))
+	expectedPerL2Tx := new(big.Int).Mul(expectedPerL1CalldataByte, big.NewInt(AssumedSimpleTxSize))
+	expectedPerStorage := new(big.Int).Mul(evm.Context.BaseFee, big.NewInt(int64(storage.StorageWriteCost)))
+	expectedCongestion := new(big.Int).Sub(evm.Context.BaseFee, minBaseFee)
+
+	check := func(name string, have, want *big.Int) {
+		t.Helper()
+		if have.Cmp(want) != 0 {
+			Fail(t, "wrong", name, "price", have, "instead of", want)
+		}
+	}
+	check("per-L2-tx", perL2Tx, expectedPerL2Tx)
+	check("per-L1-calldata-byte", perL1CalldataByte, expectedPerL1CalldataByte)
+	check("per-storage", perStorage, expectedPerStorage)
+	check("per-ArbGas base", perArbGasBase, minBaseFee)
+	check("per-ArbGas congestion", perArbGasCongestion, expectedCongestion)
+	check("per-ArbGas total", perArbGasTotal, evm.Context.BaseFee)
+}
+
+func TestGetPricesInArbGasAndAccountingParams(t *testing.T) {
+	evm := newMockEVMForTesting()
+	callCtx := testContext(common.Address{}, evm)
+	gasInfo := &ArbGasInfo{}
+
+	l1PricePerUnit := big.NewInt(1000000000)
+	evm.Context.BaseFee = big.NewInt(100000000)
+	speedLimit := uint64(9000000)
+	perBlockGasLimit := uint64(40000000)
+	Require(t, callCtx.State.L1PricingState().SetPricePerUnit(l1PricePerUnit))
+	Require(t, callCtx.State.L2PricingState().SetSpeedLimitPerSecond(speedLimit))
+	Require(t, callCtx.State.L2PricingState().SetMaxPerBlockGasLimit(perBlockGasLimit))
+
+	perL2Tx, perL1CalldataByte, perStorage, err := gasInfo.GetPricesInArbGas(callCtx, evm)
+	Require(t, err)
+
+	weiPerL1CalldataByte := new(big.Int).Mul(l1PricePerUnit, big.NewInt(int64(params.TxDataNonZeroGasEIP2028)))
+	expectedPerL1CalldataByte := new(big.Int).Div(weiPerL1CalldataByte, evm.Context.BaseFee)
+	expectedPerL2Tx := new(big.Int).Div(
+		new(big.Int).Mul(weiPerL1CalldataByte, big.NewInt(AssumedSimpleTxSize)),
+		evm.Context.BaseFee,
+	)
+	if perL2Tx.Cmp(expectedPerL2Tx) != 0 {
+		Fail(t, "wrong per-L2-tx price", perL2Tx, "instead of", expectedPerL2Tx)
+	}
+	if perL1CalldataByte.Cmp(expectedPerL1CalldataByte) != 0 {
+		Fail(t, "wrong per-L1-calldata-byte price", perL1CalldataByte, "instead of", expectedPerL1CalldataByte)
+	}
+	if perStorage.Cmp(new(big.Int).SetUint64(storage.StorageWriteCost)) != 0 {
+		Fail(t, "wrong per-storage price", perStorage)
+	}
+
+	speedLimitResult, gasPoolMax, maxTxGasLimit, err := gasInfo.GetGasAccountingParams(callCtx, evm)
+	Require(t, err)
+	if speedLimitResult.Cmp(new(big.Int).SetUint64(speedLimit)) != 0 {
+		Fail(t, "wrong speed limit", speedLimitResult, "instead of", speedLimit)
+	}
+	if gasPoolMax.Cmp(new(big.Int).SetUint64(perBlockGasLimit)) != 0 {
+		Fail(t, "wrong gas pool max", gasPoolMax, "instead of", perBlockGasLimit)
+	}
+	if maxTxGasLimit.Cmp(new(big.Int).SetUint64(perBlockGasLimit)) != 0 {
+		Fail(t, "wrong tx gas limit", maxTxGasLimit, "instead of", perBlockGasLimit)
+	}
+}
+
+func TestGetL1GasPriceEstimate(t *testing.T) {
+	evm := newMockEVMForTesting()
+	callCtx := testContext(common.Address{}, evm)
+	owner := &ArbOwner{}
+	gasInfo := &ArbGasInfo{}
+
+	// the estimate is denominated in wei, exactly as the owner sets it
+	pricePerUnit := big.NewInt(12345678901)
+	Require(t, owner.SetL1PricePerUnit(callCtx, evm, pricePerUnit))
+
+	estimate, err := gasInfo.GetL1GasPriceEstimate(callCtx, evm)
+	Require(t, err)
+	if estimate.Cmp(pricePerUnit) != 0 {
+		Fail(t, "L1 gas price estimate is", estimate, "instead of", pricePerUnit)
+	}
+	baseFeeEstimate, err := gasInfo.GetL1BaseFeeEstimate(callCtx, evm)
+	Require(t, err)
+	if baseFeeEstimate.Cmp(pricePerUnit) != 0 {
+		Fail(t, "L1 basefee estimate is", baseFeeEstimate, "instead of", pricePerUnit)
+	}
+}
+
+func TestGetMinimumGasPrice(t *testing.T) {
+	evm := newMockEVMForTesting()
+	callCtx := testContext(common.Address{}, evm)
+	owner := &ArbOwner{}
+	gasInfo := &ArbGasInfo{}
+
+	// the floor is set by the owner and read back in wei
+	minimum := big.NewInt(200000000)
+	Require(t, owner.SetMinimumL2BaseFee(callCtx, evm, minimum))
+
+	price, err := gasInfo.GetMinimumGasPrice(callCtx, evm)
+	Require(t, err)
+	if price.Cmp(minimum) != 0 {
+		Fail(t, "minimum gas price is", price, "instead of", minimum)
+	}
+}
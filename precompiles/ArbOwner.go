@@ -23,7 +23,8 @@ type ArbOwner struct {
 }
 
 var (
-	ErrOutOfBounds = errors.New("value out of bounds")
+	ErrOutOfBounds    = errors.New("value out of bounds")
+	ErrLastChainOwner = errors.New("cannot remove the last chain owner")
 )
 
 // AddChainOwner adds account as a chain owner
@@ -37,6 +38,16 @@ func (con ArbOwner) RemoveChainOwner(c ctx, evm mech, addr addr) error {
 	if !member {
 		return errors.New("tried to remove non-owner")
 	}
+	if c.State.ArbOSVersion() >= 11 {
+		// the chain must never become unownable
+		size, err := c.State.ChainOwners().Size()
+		if err != nil {
+			return err
+		}
+		if size <= 1 {
+			return ErrLastChainOwner
+		}
+	}
 	return c.State.ChainOwners().Remove(addr, c.State.ArbOSVersion())
 }
 
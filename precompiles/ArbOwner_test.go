@@ -4,6 +4,7 @@
 package precompiles
 
 import (
+	"errors"
 	"github.com/offchainlabs/nitro/arbos/l1pricing"
 	"math/big"
 	"testing"
@@ -18,6 +19,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/offchainlabs/nitro/arbos/util"
+	templates "github.com/offchainlabs/nitro/solgen/go/precompilesgen"
 )
 
 func TestArbOwner(t *testing.T) {
@@ -191,3 +193,148 @@ func TestArbInfraFeeAccount(t *testing.T) {
 		t.Fatal()
 	}
 }
+
+func TestRemoveChainOwner(t *testing.T) {
+	evm := newMockEVMForTestingAtArbosVersion(t, 11)
+	caller := common.BytesToAddress(crypto.Keccak256([]byte{})[:20])
+	callCtx := testContext(caller, evm)
+	prec := &ArbOwner{}
+
+	addr1 := common.BytesToAddress(crypto.Keccak256([]byte{1})[:20])
+	addr2 := common.BytesToAddress(crypto.Keccak256([]byte{2})[:20])
+	addr3 := common.BytesToAddress(crypto.Keccak256([]byte{3})[:20])
+
+	// the zero address is an owner by default
+	Require(t, prec.AddChainOwner(callCtx, evm, addr1))
+	Require(t, prec.AddChainOwner(callCtx, evm, addr2))
+	Require(t, prec.AddChainOwner(callCtx, evm, addr3))
+
+	// removing from the middle swaps the final owner into the vacated slot
+	Require(t, prec.RemoveChainOwner(callCtx, evm, addr2))
+	all, err := prec.GetAllChainOwners(callCtx, evm)
+	Require(t, err)
+	expected := []common.Address{{}, addr1, addr3}
+	if len(all) != len(expected) {
+		Fail(t, "wrong number of owners", all)
+	}
+	for i, owner := range expected {
+		if all[i] != owner {
+			Fail(t, "unexpected owner", all[i], "instead of", owner)
+		}
+		member, err := prec.IsChainOwner(callCtx, evm, owner)
+		Require(t, err)
+		if !member {
+			Fail(t, "owner", owner, "went missing")
+		}
+	}
+	member, err := prec.IsChainOwner(callCtx, evm, addr2)
+	Require(t, err)
+	if member {
+		Fail(t, "removed owner is still a member")
+	}
+
+	Require(t, prec.RemoveChainOwner(callCtx, evm, addr3))
+	Require(t, prec.RemoveChainOwner(callCtx, evm, common.Address{}))
+
+	// the final owner cannot be removed
+	if !errors.Is(prec.RemoveChainOwner(callCtx, evm, addr1), ErrLastChainOwner) {
+		Fail(t, "removed the last chain owner")
+	}
+	member, err = prec.IsChainOwner(callCtx, evm, addr1)
+	Require(t, err)
+	if !member {
+		Fail(t, "last chain owner was removed")
+	}
+}
+
+func TestRemoveLastChainOwnerByVersion(t *testing.T) {
+	for _, version := range []uint64{10, 11} {
+		evm := newMockEVMForTestingAtArbosVersion(t, version)
+		callCtx := testContext(common.Address{}, evm)
+		prec := &ArbOwner{}
+
+		owners, err := prec.GetAllChainOwners(callCtx, evm)
+		Require(t, err)
+		if len(owners) != 1 {
+			Fail(t, "expected a single initial owner, have", owners)
+		}
+
+		err = prec.RemoveChainOwner(callCtx, evm, owners[0])
+		if version < 11 {
+			// older versions must keep allowing the chain to become unownable
+			Require(t, err)
+		} else if !errors.Is(err, ErrLastChainOwner) {
+			Fail(t, "removing the last owner at version", version, "got", err)
+		}
+	}
+}
+
+func TestSetNetworkFeeAccountAuthorization(t *testing.T) {
+	evm := newMockEVMForTesting()
+	owner := common.BytesToAddress(crypto.Keccak256([]byte{})[:20])
+	stranger := common.BytesToAddress(crypto.Keccak256([]byte{0})[:20])
+	feeAccount := common.BytesToAddress(crypto.Keccak256([]byte{1})[:20])
+	callCtx := testContext(owner, evm)
+	Require(t, callCtx.State.ChainOwners().Add(owner))
+
+	ownerABI, err := templates.ArbOwnerMetaData.GetAbi()
+	Require(t, err)
+	calldata, err := ownerABI.Pack("setNetworkFeeAccount", feeAccount)
+	Require(t, err)
+
+	ownerAddress := common.HexToAddress("70")
+	call := func(caller common.Address) error {
+		_, _, err := Precompiles()[ownerAddress].Call(
+			calldata,
+			ownerAddress,
+			ownerAddress,
+			caller,
+			big.NewInt(0),
+			false,
+			1000000,
+			evm,
+		)
+		return err
+	}
+
+	if call(stranger) == nil {
+		Fail(t, "non-owner changed the network fee account")
+	}
+	account, err := (&ArbOwner{}).GetNetworkFeeAccount(callCtx, evm)
+	Require(t, err)
+	if account == feeAccount {
+		Fail(t, "rejected call changed the network fee account")
+	}
+
+	Require(t, call(owner))
+	account, err = (&ArbOwner{}).GetNetworkFeeAccount(callCtx, evm)
+	Require(t, err)
+	if account != feeAccount {
+		Fail(t, "network fee account is", account, "instead of", feeAccount)
+	}
+}
+
+func TestOwnerWrapperRejectsNonOwners(t *testing.T) {
+	evm := newMockEVMForTesting()
+	stranger := common.BytesToAddress(crypto.Keccak256([]byte{0})[:20])
+
+	ownerABI, err := templates.ArbOwnerMetaData.GetAbi()
+	Require(t, err)
+	calldata, err := ownerABI.Pack("addChainOwner", stranger)
+	Require(t, err)
+
+	ownerAddress := common.HexToAddress("70")
+	_, _, err = Precompiles()[ownerAddress].Call(
+		calldata,
+		ownerAddress,
+		ownerAddress,
+		stranger,
+		big.NewInt(0),
+		false,
+		1000000,
+		evm,
+	)
+	if !errors.Is(err, ErrNotOwner) || !errors.Is(err, ErrNotAuthorized) {
+		Fail(t, "non-owner call through the owner wrapper got", err)
+	}
+}
@@ -5,6 +5,7 @@ package precompiles
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -212,7 +213,7 @@ func (con ArbRetryableTx) Cancel(c ctx, evm mech, ticketId bytes32) error {
 		return err
 	}
 	if c.caller != beneficiary {
-		return errors.New("only the beneficiary may cancel a retryable")
+		return fmt.Errorf("%w: only the beneficiary may cancel a retryable", ErrNotAuthorized)
 	}
 
 	// no refunds are given for deleting retryables because they use rented space
@@ -223,6 +224,7 @@ func (con ArbRetryableTx) Cancel(c ctx, evm mech, ticketId bytes32) error {
 	return con.Canceled(c, evm, ticketId)
 }
 
+// GetCurrentRedeemer gets the address that will be refunded for the redeem being executed, or zero outside of a redeem
 func (con ArbRetryableTx) GetCurrentRedeemer(c ctx, evm mech) (common.Address, error) {
 	if c.txProcessor.CurrentRefundTo != nil {
 		return *c.txProcessor.CurrentRefundTo, nil
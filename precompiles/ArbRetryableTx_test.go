@@ -4,12 +4,16 @@
 package precompiles
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
+	"github.com/offchainlabs/nitro/arbos"
+	"github.com/offchainlabs/nitro/arbos/retryables"
 	"github.com/offchainlabs/nitro/arbos/storage"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	templates "github.com/offchainlabs/nitro/solgen/go/precompilesgen"
 )
 
@@ -63,3 +67,171 @@ func TestRetryableRedeem(t *testing.T) {
 		Fail(t, "didn't consume all the expected gas")
 	}
 }
+
+func newRetryableTxForTesting() *ArbRetryableTx {
+	//nolint:errcheck
+	return Precompiles()[types.ArbRetryableTxAddress].Precompile().implementer.Interface().(*ArbRetryableTx)
+}
+
+func createTestRetryable(t *testing.T, c ctx, id common.Hash, timeout uint64, beneficiary common.Address) {
+	t.Helper()
+	to := common.HexToAddress("0x06070809")
+	_, err := c.State.RetryableState().CreateRetryable(
+		id,
+		timeout,
+		common.HexToAddress("0x030405"),
+		&to,
+		big.NewInt(0),
+		beneficiary,
+		[]byte{0x01, 0x02},
+	)
+	Require(t, err)
+}
+
+func TestRetryableGetTimeout(t *testing.T) {
+	evm := newMockEVMForTesting()
+	precompileCtx := testContext(common.Address{}, evm)
+	retryableTx := newRetryableTxForTesting()
+
+	id := common.BigToHash(big.NewInt(978645611142))
+	timeout := evm.Context.Time + 10000000
+	createTestRetryable(t, precompileCtx, id, timeout, common.HexToAddress("0x0301040105090206"))
+
+	result, err := retryableTx.GetTimeout(precompileCtx, evm, id)
+	Require(t, err)
+	if result.Cmp(new(big.Int).SetUint64(timeout)) != 0 {
+		Fail(t, "wrong timeout", result, "instead of", timeout)
+	}
+
+	missing := common.BigToHash(big.NewInt(1))
+	if _, err := retryableTx.GetTimeout(precompileCtx, evm, missing); err == nil {
+		Fail(t, "got a timeout for a ticket that doesn't exist")
+	}
+}
+
+func TestRetryableCancel(t *testing.T) {
+	evm := newMockEVMForTesting()
+	beneficiary := common.HexToAddress("0x0301040105090206")
+	beneficiaryCtx := testContext(beneficiary, evm)
+	imposterCtx := testContext(common.HexToAddress("0x0badc0de"), evm)
+	retryableTx := newRetryableTxForTesting()
+
+	id := common.BigToHash(big.NewInt(978645611142))
+	createTestRetryable(t, beneficiaryCtx, id, evm.Context.Time+10000000, beneficiary)
+	escrowed := big.NewInt(1000000)
+	evm.StateDB.AddBalance(retryables.RetryableEscrowAddress(id), escrowed)
+
+	// only the beneficiary may cancel
+	if err := retryableTx.Cancel(imposterCtx, evm, id); !errors.Is(err, ErrNotAuthorized) {
+		Fail(t, "imposter canceling the retryable got", err, "instead of", ErrNotAuthorized)
+	}
+
+	Require(t, retryableTx.Cancel(beneficiaryCtx, evm, id))
+	if balance := evm.StateDB.GetBalance(beneficiary); balance.Cmp(escrowed) != 0 {
+		Fail(t, "beneficiary was refunded", balance, "instead of", escrowed)
+	}
+	if _, err := retryableTx.GetTimeout(beneficiaryCtx, evm, id); err == nil {
+		Fail(t, "canceled retryable still exists")
+	}
+
+	// a canceled retryable can't be canceled again
+	if err := retryableTx.Cancel(beneficiaryCtx, evm, id); err == nil {
+		Fail(t, "canceled the retryable twice")
+	}
+}
+
+func TestRetryableKeepalive(t *testing.T) {
+	evm := newMockEVMForTesting()
+	precompileCtx := testContext(common.Address{}, evm)
+	retryableTx := newRetryableTxForTesting()
+
+	id := common.BigToHash(big.NewInt(978645611142))
+	timeout := evm.Context.Time + 1000
+	createTestRetryable(t, precompileCtx, id, timeout, common.HexToAddress("0x0301040105090206"))
+
+	// keepalive adds exactly one lifetime to the current timeout
+	newTimeout, err := retryableTx.Keepalive(precompileCtx, evm, id)
+	Require(t, err)
+	expected := timeout + retryables.RetryableLifetimeSeconds
+	if newTimeout.Cmp(new(big.Int).SetUint64(expected)) != 0 {
+		Fail(t, "keepalive extended the timeout to", newTimeout, "instead of", expected)
+	}
+	result, err := retryableTx.GetTimeout(precompileCtx, evm, id)
+	Require(t, err)
+	if result.Cmp(newTimeout) != 0 {
+		Fail(t, "stored timeout", result, "doesn't match", newTimeout)
+	}
+
+	// a ticket can't be extended more than one lifetime past the current time
+	if _, err := retryableTx.Keepalive(precompileCtx, evm, id); err == nil {
+		Fail(t, "extended the ticket past the maximum lifetime")
+	}
+
+	// once enough time has passed, the ticket can be extended again
+	evm.Context.Time = timeout
+	newTimeout, err = retryableTx.Keepalive(precompileCtx, evm, id)
+	Require(t, err)
+	expected += retryables.RetryableLifetimeSeconds
+	if newTimeout.Cmp(new(big.Int).SetUint64(expected)) != 0 {
+		Fail(t, "keepalive extended the timeout to", newTimeout, "instead of", expected)
+	}
+
+	// expired tickets can't be kept alive
+	evm.Context.Time = expected + 1
+	if _, err := retryableTx.Keepalive(precompileCtx, evm, id); err == nil {
+		Fail(t, "kept an expired ticket alive")
+	}
+}
+
+func TestRetryableGetBeneficiaryAndLifetime(t *testing.T) {
+	evm := newMockEVMForTesting()
+	precompileCtx := testContext(common.Address{}, evm)
+	retryableTx := newRetryableTxForTesting()
+
+	lifetime, err := retryableTx.GetLifetime(precompileCtx, evm)
+	Require(t, err)
+	if lifetime.Cmp(big.NewInt(retryables.RetryableLifetimeSeconds)) != 0 {
+		Fail(t, "wrong lifetime", lifetime)
+	}
+
+	id := common.BigToHash(big.NewInt(978645611142))
+	beneficiary := common.HexToAddress("0x0301040105090206")
+	createTestRetryable(t, precompileCtx, id, evm.Context.Time+10000000, beneficiary)
+
+	result, err := retryableTx.GetBeneficiary(precompileCtx, evm, id)
+	Require(t, err)
+	if result != beneficiary {
+		Fail(t, "wrong beneficiary", result, "instead of", beneficiary)
+	}
+
+	missing := common.BigToHash(big.NewInt(1))
+	if _, err := retryableTx.GetBeneficiary(precompileCtx, evm, missing); err == nil {
+		Fail(t, "got a beneficiary for a ticket that doesn't exist")
+	}
+}
+
+func TestRetryableGetCurrentRedeemer(t *testing.T) {
+	evm := newMockEVMForTesting()
+	callCtx := testContext(common.Address{}, evm)
+	retryableTx := newRetryableTxForTesting()
+
+	redeemer, err := retryableTx.GetCurrentRedeemer(callCtx, evm)
+	Require(t, err)
+	if redeemer != (common.Address{}) {
+		Fail(t, "got redeemer", redeemer, "outside of a redeem")
+	}
+
+	// the tx processor tracks who to refund while a retry tx executes
+	refundTo := common.HexToAddress("0x5678")
+	ticketId := common.BigToHash(big.NewInt(978645611142))
+	//nolint:errcheck
+	txProcessor := evm.ProcessingHook.(*arbos.TxProcessor)
+	txProcessor.CurrentRetryable = &ticketId
+	txProcessor.CurrentRefundTo = &refundTo
+
+	redeemer, err = retryableTx.GetCurrentRedeemer(testContext(common.Address{}, evm), evm)
+	Require(t, err)
+	if redeemer != refundTo {
+		Fail(t, "got redeemer", redeemer, "instead of", refundTo)
+	}
+}
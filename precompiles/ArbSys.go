@@ -5,6 +5,7 @@ package precompiles
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -29,12 +30,12 @@ type ArbSys struct {
 	L2ToL1TransactionGasCost func(addr, addr, huge, huge, huge, huge, huge, huge, huge, []byte) (uint64, error)
 }
 
-// ArbBlockNumber gets the current L2 block number
+// ArbBlockNumber gets the current L2 block number, unlike the NUMBER opcode which gives an L1 block number
 func (con *ArbSys) ArbBlockNumber(c ctx, evm mech) (huge, error) {
 	return evm.Context.BlockNumber, nil
 }
 
-// ArbBlockHash gets the L2 block hash, if sufficiently recent
+// ArbBlockHash gets the L2 block hash of one of the 256 blocks preceding the current one
 func (con *ArbSys) ArbBlockHash(c ctx, evm mech, arbBlockNumber *big.Int) (bytes32, error) {
 	if !arbBlockNumber.IsUint64() {
 		if c.State.ArbOSVersion() >= 11 {
@@ -192,7 +193,7 @@ func (con *ArbSys) SendTxToL1(c ctx, evm mech, value huge, destination addr, cal
 // SendMerkleTreeState gets the root, size, and partials of the outbox Merkle tree state (caller must be the 0 address)
 func (con ArbSys) SendMerkleTreeState(c ctx, evm mech) (huge, bytes32, []bytes32, error) {
 	if c.caller != (addr{}) {
-		return nil, bytes32{}, nil, errors.New("method can only be called by address zero")
+		return nil, bytes32{}, nil, fmt.Errorf("%w: method can only be called by address zero", ErrNotAuthorized)
 	}
 
 	// OK to not charge gas, because method is only callable by address zero
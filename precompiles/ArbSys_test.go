@@ -0,0 +1,179 @@
+// Copyright 2021-2023, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package precompiles
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/offchainlabs/nitro/arbos/util"
+	templates "github.com/offchainlabs/nitro/solgen/go/precompilesgen"
+)
+
+func TestArbSysDispatch(t *testing.T) {
+	evm := newMockEVMForTesting()
+	evm.Context.BlockNumber = big.NewInt(1234)
+
+	sysABI, err := templates.ArbSysMetaData.GetAbi()
+	Require(t, err)
+	calldata, err := sysABI.Pack("arbBlockNumber")
+	Require(t, err)
+
+	sysAddress := types.ArbSysAddress
+	output, _, err := Precompiles()[sysAddress].Call(
+		calldata,
+		sysAddress,
+		sysAddress,
+		common.Address{},
+		big.NewInt(0),
+		true,
+		1000000,
+		evm,
+	)
+	Require(t, err)
+
+	results, err := sysABI.Unpack("arbBlockNumber", output)
+	Require(t, err)
+	if len(results) != 1 {
+		Fail(t, "unexpected number of results", len(results))
+	}
+	blockNumber, ok := results[0].(*big.Int)
+	if !ok || blockNumber.Cmp(evm.Context.BlockNumber) != 0 {
+		Fail(t, "dispatched call returned", results[0], "instead of", evm.Context.BlockNumber)
+	}
+
+	// an unknown selector should revert rather than reach any method
+	_, _, err = Precompiles()[sysAddress].Call(
+		[]byte{0xde, 0xad, 0xbe, 0xef},
+		sysAddress,
+		sysAddress,
+		common.Address{},
+		big.NewInt(0),
+		true,
+		1000000,
+		evm,
+	)
+	if err == nil {
+		Fail(t, "unknown selector should revert")
+	}
+}
+
+func TestArbBlockNumber(t *testing.T) {
+	evm := newMockEVMForTesting()
+	callCtx := testContext(common.Address{}, evm)
+	sys := &ArbSys{}
+
+	// the L1 block number is what the NUMBER opcode sees, and must not leak into the L2 number
+	l1BlockNumber := uint64(17000000)
+	Require(t, callCtx.State.Blockhashes().RecordNewL1Block(l1BlockNumber, common.Hash{1}, callCtx.State.ArbOSVersion()))
+
+	for _, number := range []int64{0, 1, 2000000} {
+		evm.Context.BlockNumber = big.NewInt(number)
+		blockNumber, err := sys.ArbBlockNumber(callCtx, evm)
+		Require(t, err)
+		if blockNumber.Cmp(big.NewInt(number)) != 0 {
+			Fail(t, "ArbBlockNumber returned", blockNumber, "instead of", number)
+		}
+	}
+}
+
+func TestArbBlockHash(t *testing.T) {
+	evm := newMockEVMForTesting()
+	evm.Context.BlockNumber = big.NewInt(1000)
+	evm.Context.GetHash = func(number uint64) common.Hash {
+		return common.BigToHash(new(big.Int).SetUint64(number + 1))
+	}
+	callCtx := testContext(common.Address{}, evm)
+
+	//nolint:errcheck
+	sys := Precompiles()[types.ArbSysAddress].Precompile().implementer.Interface().(*ArbSys)
+
+	// the previous 256 blocks are available
+	for _, number := range []int64{999, 900, 744} {
+		blockHash, err := sys.ArbBlockHash(callCtx, evm, big.NewInt(number))
+		Require(t, err)
+		if blockHash != evm.Context.GetHash(uint64(number)) {
+			Fail(t, "wrong hash for block", number, blockHash)
+		}
+	}
+
+	// older blocks, the current block, and future blocks are not
+	tooLarge := new(big.Int).Lsh(common.Big1, 64)
+	for _, number := range []*big.Int{big.NewInt(743), big.NewInt(1000), big.NewInt(1001), tooLarge} {
+		if _, err := sys.ArbBlockHash(callCtx, evm, number); err == nil {
+			Fail(t, "got a hash for block", number)
+		}
+	}
+}
+
+func TestArbChainID(t *testing.T) {
+	mockEVM := newMockEVMForTesting()
+	chainConfig := *mockEVM.ChainConfig()
+	chainConfig.ChainID = big.NewInt(421613)
+	evm := vm.NewEVM(mockEVM.Context, vm.TxContext{}, mockEVM.StateDB, &chainConfig, vm.Config{})
+	evm.ProcessingHook = mockEVM.ProcessingHook
+
+	chainID, err := (&ArbSys{}).ArbChainID(testContext(common.Address{}, evm), evm)
+	Require(t, err)
+	if chainID.Cmp(chainConfig.ChainID) != 0 {
+		Fail(t, "ArbChainID returned", chainID, "instead of", chainConfig.ChainID)
+	}
+}
+
+func TestArbOSVersion(t *testing.T) {
+	version := uint64(10)
+	evm := newMockEVMForTestingAtArbosVersion(t, version)
+	callCtx := testContext(common.Address{}, evm)
+	if callCtx.State.ArbOSVersion() != version {
+		Fail(t, "state initialized at version", callCtx.State.ArbOSVersion(), "instead of", version)
+	}
+	sys := &ArbSys{}
+
+	// Nitro's first ArbOS version is reported as 56
+	reported, err := sys.ArbOSVersion(callCtx, evm)
+	Require(t, err)
+	if reported.Uint64() != 55+version {
+		Fail(t, "ArbOSVersion returned", reported, "instead of", 55+version)
+	}
+
+	// schedule an upgrade the way the chain owner would, then let the next block apply it
+	Require(t, (&ArbOwner{}).ScheduleArbOSUpgrade(callCtx, evm, version+1, 0))
+	Require(t, callCtx.State.UpgradeArbosVersionIfNecessary(0, evm.StateDB, evm.ChainConfig()))
+
+	callCtx = testContext(common.Address{}, evm)
+	if callCtx.State.ArbOSVersion() != version+1 {
+		Fail(t, "upgrade left the state at version", callCtx.State.ArbOSVersion())
+	}
+	reported, err = sys.ArbOSVersion(callCtx, evm)
+	Require(t, err)
+	if reported.Uint64() != 55+version+1 {
+		Fail(t, "ArbOSVersion returned", reported, "after upgrading to", version+1)
+	}
+}
+
+func TestMapL1SenderContractAddressToL2Alias(t *testing.T) {
+	evm := newMockEVMForTesting()
+	callCtx := testContext(common.Address{}, evm)
+	sys := &ArbSys{}
+
+	fixtures := map[common.Address]common.Address{
+		common.HexToAddress("0x0000000000000000000000000000000000000000"): common.HexToAddress("0x1111000000000000000000000000000000001111"),
+		common.HexToAddress("0x0000000000000000000000000000000000000001"): common.HexToAddress("0x1111000000000000000000000000000000001112"),
+		common.HexToAddress("0xffffffffffffffffffffffffffffffffffffffff"): common.HexToAddress("0x1111000000000000000000000000000000001110"),
+		common.HexToAddress("0xeeeeffffffffffffffffffffffffffffffffeeef"): common.HexToAddress("0x0000000000000000000000000000000000000000"),
+	}
+	for l1Address, expected := range fixtures {
+		alias, err := sys.MapL1SenderContractAddressToL2Alias(callCtx, l1Address, common.Address{})
+		Require(t, err)
+		if alias != expected {
+			Fail(t, "aliased", l1Address, "to", alias, "instead of", expected)
+		}
+		if util.InverseRemapL1Address(alias) != l1Address {
+			Fail(t, "failed to undo the alias of", l1Address)
+		}
+	}
+}
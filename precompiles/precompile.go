@@ -8,9 +8,12 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/offchainlabs/nitro/arbos"
 	"github.com/offchainlabs/nitro/arbos/arbosState"
@@ -26,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	glog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -66,6 +70,7 @@ type Precompile struct {
 	implementer   reflect.Value
 	address       common.Address
 	arbosVersion  uint64
+	source        abi.ABI
 }
 
 type PrecompileMethod struct {
@@ -74,6 +79,8 @@ type PrecompileMethod struct {
 	purity       purity
 	handler      reflect.Method
 	arbosVersion uint64
+	calls        metrics.Counter // number of dispatches, when metrics are enabled
+	gasUsed      metrics.Counter // gas charged across those dispatches
 }
 
 type PrecompileEvent struct {
@@ -162,14 +169,16 @@ func MakePrecompile(metadata *bind.MetaData, implementer interface{}) (addr, *Pr
 
 	for _, method := range source.Methods {
 
-		name := method.RawName
-		capitalize := string(unicode.ToUpper(rune(name[0])))
-		name = capitalize + name[1:]
+		// geth disambiguates overloads by suffixing their names (foo, foo0, foo1...), as must the implementer
+		name := capitalize(method.Name)
 
 		if len(method.ID) != 4 {
 			log.Crit("Method ID isn't 4 bytes")
 		}
 		id := *(*[4]byte)(method.ID)
+		if _, ok := methods[id]; ok {
+			log.Crit("Precompile " + contract + " has multiple methods with selector " + common.Bytes2Hex(id[:]))
+		}
 
 		// check that the implementer has a supporting implementation for this method
 
@@ -221,12 +230,15 @@ func MakePrecompile(metadata *bind.MetaData, implementer interface{}) (addr, *Pr
 			)
 		}
 
+		metricsPrefix := "arb/precompile/" + strings.ToLower(contract+"/"+name) + "/"
 		method := PrecompileMethod{
 			name,
 			method,
 			purity,
 			handler,
 			0,
+			metrics.NewRegisteredCounter(metricsPrefix+"calls", nil),
+			metrics.NewRegisteredCounter(metricsPrefix+"gas", nil),
 		}
 		methods[id] = &method
 		methodsByName[name] = &method
@@ -512,10 +524,23 @@ func MakePrecompile(metadata *bind.MetaData, implementer interface{}) (addr, *Pr
 		reflect.ValueOf(implementer),
 		address,
 		0,
+		source,
 	}
 }
 
+var precompilesOnce sync.Once
+var precompilesByAddress map[addr]ArbosPrecompile
+
+// Precompiles returns the ArbOS precompiles by address.
+// The map is built once and then shared, so callers must not modify it.
 func Precompiles() map[addr]ArbosPrecompile {
+	precompilesOnce.Do(func() {
+		precompilesByAddress = makePrecompiles()
+	})
+	return precompilesByAddress
+}
+
+func makePrecompiles() map[addr]ArbosPrecompile {
 
 	//nolint:gocritic
 	hex := func(s string) addr {
@@ -525,6 +550,9 @@ func Precompiles() map[addr]ArbosPrecompile {
 	contracts := make(map[addr]ArbosPrecompile)
 
 	insert := func(address addr, impl ArbosPrecompile) *Precompile {
+		if existing, ok := contracts[address]; ok {
+			log.Crit("Precompiles " + existing.Precompile().name + " and " + impl.Precompile().name + " share address " + address.Hex())
+		}
 		contracts[address] = impl
 		return impl.Precompile()
 	}
@@ -596,6 +624,12 @@ func Precompiles() map[addr]ArbosPrecompile {
 	return contracts
 }
 
+// PrecompileAt returns the ArbOS precompile at the given address, if there is one
+func PrecompileAt(address addr) (ArbosPrecompile, bool) {
+	precompile, ok := Precompiles()[address]
+	return precompile, ok
+}
+
 func (p *Precompile) CloneWithImpl(impl interface{}) *Precompile {
 	clone := *p
 	clone.implementer = reflect.ValueOf(impl)
@@ -654,6 +688,11 @@ func (p *Precompile) Call(
 		return nil, 0, vm.ErrExecutionReverted
 	}
 
+	method.calls.Inc(1)
+	defer func() {
+		method.gasUsed.Inc(int64(gasSupplied - gasLeft))
+	}()
+
 	callerCtx := &Context{
 		caller:      caller,
 		gasSupplied: gasSupplied,
@@ -749,6 +788,12 @@ func (p *Precompile) Call(
 	result := make([]interface{}, resultCount)
 	for i := 0; i < resultCount; i++ {
 		result[i] = reflectResult[i].Interface()
+		value, isBig := result[i].(*big.Int)
+		if arbosVersion >= 11 && isBig && !bigFitsAbiType(value, method.template.Outputs[i].Type) {
+			// geth's abi packing would silently truncate the value
+			log.Error("precompile returned an out of range value", "precompile", precompileAddress, "method", method.name, "value", value)
+			return nil, callerCtx.gasLeft, vm.ErrExecutionReverted
+		}
 	}
 
 	encoded, err := method.template.Outputs.PackValues(result)
@@ -766,6 +811,34 @@ func (p *Precompile) Call(
 	return encoded, callerCtx.gasLeft, nil
 }
 
+// capitalize upper-cases the first rune of a solidity name to get the matching exported go name
+func capitalize(name string) string {
+	first, size := utf8.DecodeRuneInString(name)
+	if size == 0 {
+		return name
+	}
+	return string(unicode.ToUpper(first)) + name[size:]
+}
+
+// bigFitsAbiType checks that a value can be encoded as the given integer type without truncation
+func bigFitsAbiType(value *big.Int, typ abi.Type) bool {
+	if value == nil {
+		return false
+	}
+	switch typ.T {
+	case abi.UintTy:
+		return value.Sign() >= 0 && value.BitLen() <= typ.Size
+	case abi.IntTy:
+		if value.Sign() < 0 {
+			// for negative values, the two's complement magnitude is -value - 1
+			return new(big.Int).Not(value).BitLen() < typ.Size
+		}
+		return value.BitLen() < typ.Size
+	default:
+		return true
+	}
+}
+
 func (p *Precompile) Precompile() *Precompile {
 	return p
 }
@@ -779,6 +852,34 @@ func (p *Precompile) Get4ByteMethodSignatures() [][4]byte {
 	return ret
 }
 
+// ABI returns a copy of the parsed interface the precompile was built from
+func (p *Precompile) ABI() abi.ABI {
+	source := p.source
+	source.Methods = make(map[string]abi.Method, len(p.source.Methods))
+	for name, method := range p.source.Methods {
+		source.Methods[name] = method
+	}
+	source.Events = make(map[string]abi.Event, len(p.source.Events))
+	for name, event := range p.source.Events {
+		source.Events[name] = event
+	}
+	source.Errors = make(map[string]abi.Error, len(p.source.Errors))
+	for name, solErr := range p.source.Errors {
+		source.Errors[name] = solErr
+	}
+	return source
+}
+
+// Methods returns the sorted names of the methods this precompile supports
+func (p *Precompile) Methods() []string {
+	names := make([]string, 0, len(p.methodsByName))
+	for name := range p.methodsByName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (p *Precompile) GetErrorABIs() []abi.Error {
 	ret := make([]abi.Error, 0, len(p.errors))
 	for _, solErr := range p.errors {
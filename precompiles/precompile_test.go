@@ -5,13 +5,21 @@ package precompiles
 
 import (
 	"bytes"
+	"errors"
 	"math/big"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/core/state"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/offchainlabs/nitro/arbos/storage"
 	templates "github.com/offchainlabs/nitro/solgen/go/precompilesgen"
@@ -198,3 +206,441 @@ func (burner FatalBurner) Burn(amount uint64) error {
 	burner.gasLeft -= amount
 	return nil
 }
+
+func TestReadOnlyEnforcement(t *testing.T) {
+	evm := newMockEVMForTesting()
+
+	tableABI, err := templates.ArbAddressTableMetaData.GetAbi()
+	Require(t, err)
+	registerCalldata, err := tableABI.Pack("register", common.HexToAddress("0x0123"))
+	Require(t, err)
+	sizeCalldata, err := tableABI.Pack("size")
+	Require(t, err)
+
+	tableAddress := common.HexToAddress("66")
+	call := func(input []byte, readOnly bool) error {
+		_, _, err := Precompiles()[tableAddress].Call(
+			input,
+			tableAddress,
+			tableAddress,
+			common.Address{},
+			big.NewInt(0),
+			readOnly,
+			1000000,
+			evm,
+		)
+		return err
+	}
+
+	// nonpayable methods must not run in a static context
+	if call(registerCalldata, true) == nil {
+		Fail(t, "write method succeeded in read-only mode")
+	}
+	size, err := testContext(common.Address{}, evm).State.AddressTable().Size()
+	Require(t, err)
+	if size != 0 {
+		Fail(t, "read-only call modified the address table")
+	}
+
+	// view methods are fine either way
+	Require(t, call(sizeCalldata, true))
+	Require(t, call(registerCalldata, false))
+}
+
+func TestPrecompileMethods(t *testing.T) {
+	ownerABI, err := templates.ArbOwnerMetaData.GetAbi()
+	Require(t, err)
+
+	methods := Precompiles()[common.HexToAddress("70")].Precompile().Methods()
+	if len(methods) != len(ownerABI.Methods) {
+		Fail(t, "ArbOwner has", len(methods), "methods but its ABI has", len(ownerABI.Methods))
+	}
+	for i, name := range methods {
+		if i > 0 && methods[i-1] >= name {
+			Fail(t, "methods aren't sorted", methods)
+		}
+		solName := strings.ToLower(name[:1]) + name[1:]
+		if _, ok := ownerABI.Methods[solName]; !ok {
+			Fail(t, "ArbOwner's ABI has no method", solName)
+		}
+	}
+}
+
+func TestBigFitsAbiType(t *testing.T) {
+	newType := func(name string) abi.Type {
+		typ, err := abi.NewType(name, "", nil)
+		Require(t, err)
+		return typ
+	}
+	uint256 := newType("uint256")
+	int256 := newType("int256")
+	uint64Type := newType("uint64")
+
+	maxUint256 := new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 256), common.Big1)
+	maxInt256 := new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 255), common.Big1)
+	minInt256 := new(big.Int).Neg(new(big.Int).Lsh(common.Big1, 255))
+
+	tests := []struct {
+		value    *big.Int
+		typ      abi.Type
+		expected bool
+	}{
+		{common.Big0, uint256, true},
+		{maxUint256, uint256, true},
+		{new(big.Int).Add(maxUint256, common.Big1), uint256, false},
+		{big.NewInt(-1), uint256, false},
+		{maxInt256, int256, true},
+		{new(big.Int).Add(maxInt256, common.Big1), int256, false},
+		{minInt256, int256, true},
+		{new(big.Int).Sub(minInt256, common.Big1), int256, false},
+		{new(big.Int).SetUint64(^uint64(0)), uint64Type, true},
+		{new(big.Int).Lsh(common.Big1, 64), uint64Type, false},
+		{big.NewInt(-1), uint64Type, false},
+		{nil, uint256, false},
+	}
+	for _, test := range tests {
+		if bigFitsAbiType(test.value, test.typ) != test.expected {
+			Fail(t, "wrong range check for", test.value, "as", test.typ.String())
+		}
+	}
+}
+
+type oversizedForTesting struct {
+	Address addr
+}
+
+func (con *oversizedForTesting) Get(c ctx, evm mech) (huge, error) {
+	return new(big.Int).Lsh(common.Big1, 256), nil
+}
+
+func TestOversizedResult(t *testing.T) {
+	metadata := &bind.MetaData{
+		ABI: `[{"inputs":[],"name":"get","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`,
+	}
+	address := common.HexToAddress("0xfff1")
+	_, oversized := MakePrecompile(metadata, &oversizedForTesting{Address: address})
+	source, err := abi.JSON(strings.NewReader(metadata.ABI))
+	Require(t, err)
+	calldata, err := source.Pack("get")
+	Require(t, err)
+
+	call := func(evm *vm.EVM) ([]byte, error) {
+		output, _, err := oversized.Call(calldata, address, address, common.Address{}, big.NewInt(0), true, 1000000, evm)
+		return output, err
+	}
+
+	// before ArbOS 11 the value silently wraps mod 2^256, which must be preserved for consensus
+	output, err := call(newMockEVMForTestingAtArbosVersion(t, 10))
+	Require(t, err)
+	if !bytes.Equal(output, common.Hash{}.Bytes()) {
+		Fail(t, "expected the value to wrap to zero before ArbOS 11, got", output)
+	}
+
+	// from ArbOS 11 on the call reverts rather than returning a value the method never produced
+	output, err = call(newMockEVMForTestingAtArbosVersion(t, 11))
+	if !errors.Is(err, vm.ErrExecutionReverted) {
+		Fail(t, "oversized result didn't revert:", err)
+	}
+	if len(output) != 0 {
+		Fail(t, "oversized result reverted with data", output)
+	}
+}
+
+func TestDelegateCallEnforcement(t *testing.T) {
+	evm := newMockEVMForTesting()
+	owner := common.HexToAddress("0x0123")
+	Require(t, testContext(common.Address{}, evm).State.ChainOwners().Add(owner))
+
+	ownerABI, err := templates.ArbOwnerMetaData.GetAbi()
+	Require(t, err)
+	getOwnersCalldata, err := ownerABI.Pack("getAllChainOwners")
+	Require(t, err)
+	sysABI, err := templates.ArbSysMetaData.GetAbi()
+	Require(t, err)
+	aliasCalldata, err := sysABI.Pack("mapL1SenderContractAddressToL2Alias", owner, common.Address{})
+	Require(t, err)
+
+	// a delegatecall or callcode runs the precompile's code on behalf of another contract
+	otherContract := common.HexToAddress("0xabcd")
+	call := func(input []byte, precompileAddress common.Address) error {
+		_, _, err := Precompiles()[precompileAddress].Call(
+			input,
+			precompileAddress,
+			otherContract,
+			owner,
+			big.NewInt(0),
+			false,
+			1000000,
+			evm,
+		)
+		return err
+	}
+
+	// impure methods can't be trusted with the caller's identity, even when it's a chain owner
+	if call(getOwnersCalldata, common.HexToAddress("70")) == nil {
+		Fail(t, "delegatecall into ArbOwner succeeded")
+	}
+
+	// pure methods don't depend on who's calling
+	Require(t, call(aliasCalldata, types.ArbSysAddress))
+}
+
+func TestCapitalize(t *testing.T) {
+	tests := map[string]string{
+		"":               "",
+		"x":              "X",
+		"arbBlockNumber": "ArbBlockNumber",
+		"Events":         "Events",
+		"émettre":        "Émettre",
+		"δ":              "Δ",
+	}
+	for name, expected := range tests {
+		if capitalize(name) != expected {
+			Fail(t, "capitalized", name, "as", capitalize(name), "instead of", expected)
+		}
+	}
+}
+
+func TestMethodMetrics(t *testing.T) {
+	// counters made while metrics are disabled are no-ops, so build a fresh precompile
+	enabled := metrics.Enabled
+	metrics.Enabled = true
+	t.Cleanup(func() { metrics.Enabled = enabled })
+	_, sys := MakePrecompile(templates.ArbSysMetaData, &ArbSys{})
+
+	evm := newMockEVMForTesting()
+	sysABI, err := templates.ArbSysMetaData.GetAbi()
+	Require(t, err)
+	calldata, err := sysABI.Pack("arbBlockNumber")
+	Require(t, err)
+
+	calls := 7
+	gasSupplied := uint64(1000000)
+	gasUsed := uint64(0)
+	for i := 0; i < calls; i++ {
+		_, gasLeft, err := sys.Call(
+			calldata,
+			types.ArbSysAddress,
+			types.ArbSysAddress,
+			common.Address{},
+			big.NewInt(0),
+			true,
+			gasSupplied,
+			evm,
+		)
+		Require(t, err)
+		gasUsed += gasSupplied - gasLeft
+	}
+
+	// calls rejected before dispatch, such as a delegatecall into a view method, aren't counted
+	_, _, err = sys.Call(
+		calldata,
+		types.ArbSysAddress,
+		common.HexToAddress("0xabcd"),
+		common.Address{},
+		big.NewInt(0),
+		true,
+		gasSupplied,
+		evm,
+	)
+	if err == nil {
+		Fail(t, "delegatecall into a view method succeeded")
+	}
+
+	method := sys.methodsByName["ArbBlockNumber"]
+	if method.calls.Count() != int64(calls) {
+		Fail(t, "counted", method.calls.Count(), "calls instead of", calls)
+	}
+	if method.gasUsed.Count() != int64(gasUsed) {
+		Fail(t, "counted", method.gasUsed.Count(), "gas instead of", gasUsed)
+	}
+}
+
+type overloadedForTesting struct {
+	Address addr
+}
+
+func (con *overloadedForTesting) Get(c ctx, evm mech) (uint64, error) {
+	return 1, nil
+}
+
+func (con *overloadedForTesting) Get0(c ctx, evm mech, value uint64) (uint64, error) {
+	return value, nil
+}
+
+func TestOverloadedMethods(t *testing.T) {
+	output := `[{"internalType":"uint64","name":"","type":"uint64"}]`
+	metadata := &bind.MetaData{
+		ABI: `[
+			{"inputs":[],"name":"get","outputs":` + output + `,"stateMutability":"view","type":"function"},
+			{"inputs":[{"internalType":"uint64","name":"value","type":"uint64"}],"name":"get","outputs":` + output + `,"stateMutability":"view","type":"function"}
+		]`,
+	}
+	address := common.HexToAddress("0xfff0")
+	_, overloaded := MakePrecompile(metadata, &overloadedForTesting{Address: address})
+
+	source, err := abi.JSON(strings.NewReader(metadata.ABI))
+	Require(t, err)
+	evm := newMockEVMForTesting()
+	call := func(name string, args ...interface{}) uint64 {
+		t.Helper()
+		calldata, err := source.Pack(name, args...)
+		Require(t, err)
+		output, _, err := overloaded.Call(calldata, address, address, common.Address{}, big.NewInt(0), true, 1000000, evm)
+		Require(t, err)
+		results, err := source.Unpack(name, output)
+		Require(t, err)
+		//nolint:errcheck
+		return results[0].(uint64)
+	}
+
+	// each overload has its own selector and dispatches to its own implementation
+	if result := call("get"); result != 1 {
+		Fail(t, "get() returned", result)
+	}
+	if result := call("get0", uint64(7)); result != 7 {
+		Fail(t, "get(uint64) returned", result)
+	}
+}
+
+func TestTruncatedCalldata(t *testing.T) {
+	evm := newMockEVMForTesting()
+	callCtx := testContext(common.Address{}, evm)
+
+	// compress a registered address so that decompressing the payload succeeds
+	registered := common.HexToAddress("0x0123")
+	_, err := ArbAddressTable{}.Register(callCtx, evm, registered)
+	Require(t, err)
+	payload, err := ArbAddressTable{}.Compress(callCtx, evm, registered)
+	Require(t, err)
+
+	tableABI, err := templates.ArbAddressTableMetaData.GetAbi()
+	Require(t, err)
+	decompressCalldata, err := tableABI.Pack("decompress", payload, big.NewInt(0))
+	Require(t, err)
+	sysABI, err := templates.ArbSysMetaData.GetAbi()
+	Require(t, err)
+	aliasCalldata, err := sysABI.Pack("mapL1SenderContractAddressToL2Alias", common.Address{}, common.Address{})
+	Require(t, err)
+
+	call := func(address common.Address, input []byte) ([]byte, error) {
+		output, _, err := Precompiles()[address].Call(
+			input,
+			address,
+			address,
+			common.Address{},
+			big.NewInt(0),
+			true,
+			1000000,
+			evm,
+		)
+		return output, err
+	}
+
+	tableAddress := common.HexToAddress("66")
+	expected, err := call(tableAddress, decompressCalldata)
+	Require(t, err)
+
+	// the selector, the two head words, and the length word, followed by the payload's significant bytes
+	significant := 4 + 3*32 + len(payload)
+	tests := []struct {
+		address     common.Address
+		calldata    []byte
+		significant int
+	}{
+		{tableAddress, decompressCalldata, significant},
+		{types.ArbSysAddress, aliasCalldata, len(aliasCalldata)},
+	}
+	for _, test := range tests {
+		for length := 0; length < test.significant; length++ {
+			if _, err := call(test.address, test.calldata[:length]); err == nil {
+				Fail(t, "calldata truncated to", length, "bytes didn't revert for", test.address)
+			}
+		}
+	}
+
+	// geth's decoder reads dynamic bytes by their length word, so dropping only
+	// the trailing zero padding leaves the arguments intact and the call succeeds
+	for length := significant; length < len(decompressCalldata); length++ {
+		output, err := call(tableAddress, decompressCalldata[:length])
+		Require(t, err, "calldata missing only padding reverted")
+		if !bytes.Equal(output, expected) {
+			Fail(t, "calldata missing only padding decompressed differently")
+		}
+	}
+}
+
+func TestPrecompileABI(t *testing.T) {
+	for address, precompile := range Precompiles() {
+		inner := precompile.Precompile()
+		source := inner.ABI()
+		if len(source.Methods) != len(inner.methods) {
+			Fail(t, "precompile at", address, "has", len(inner.methods), "methods but its ABI has", len(source.Methods))
+		}
+		for _, method := range source.Methods {
+			if _, ok := inner.methods[*(*[4]byte)(method.ID)]; !ok {
+				Fail(t, "precompile at", address, "has no method for", method.Sig)
+			}
+		}
+	}
+}
+
+func TestPrecompileABIIsCopied(t *testing.T) {
+	inner := Precompiles()[types.ArbSysAddress].Precompile()
+	original := inner.ABI()
+
+	modified := inner.ABI()
+	for name := range modified.Methods {
+		delete(modified.Methods, name)
+	}
+	for name := range modified.Events {
+		delete(modified.Events, name)
+	}
+	modified.Errors["Bogus"] = abi.Error{}
+
+	after := inner.ABI()
+	if len(after.Methods) != len(original.Methods) || len(after.Events) != len(original.Events) {
+		Fail(t, "modifying the returned ABI changed the precompile's")
+	}
+	if _, ok := after.Errors["Bogus"]; ok {
+		Fail(t, "modifying the returned ABI's errors changed the precompile's")
+	}
+}
+
+func TestPrecompileAt(t *testing.T) {
+	for _, address := range []common.Address{types.ArbSysAddress, common.HexToAddress("70"), common.HexToAddress("ff")} {
+		precompile, ok := PrecompileAt(address)
+		if !ok || precompile.Precompile().address != address {
+			Fail(t, "no precompile found at", address)
+		}
+	}
+
+	// the Ethereum precompiles and unused addresses in the ArbOS range aren't ArbOS precompiles
+	for _, address := range []common.Address{common.HexToAddress("01"), common.HexToAddress("6a"), common.HexToAddress("71")} {
+		if _, ok := PrecompileAt(address); ok {
+			Fail(t, "found a precompile at", address)
+		}
+	}
+}
+
+func TestPrecompilesAreCached(t *testing.T) {
+	first := Precompiles()
+	second := Precompiles()
+	if reflect.ValueOf(first).Pointer() != reflect.ValueOf(second).Pointer() {
+		Fail(t, "precompiles were rebuilt")
+	}
+	for address, precompile := range first {
+		if second[address] != precompile {
+			Fail(t, "precompile at", address, "was rebuilt")
+		}
+		if found, _ := PrecompileAt(address); found != precompile {
+			Fail(t, "PrecompileAt disagrees with Precompiles at", address)
+		}
+	}
+}
+
+func BenchmarkPrecompiles(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Precompiles()
+	}
+}
@@ -5,6 +5,7 @@ package precompiles
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/offchainlabs/nitro/arbos/arbosState"
@@ -57,6 +58,23 @@ type OwnerPrecompile struct {
 	emitSuccess func(mech, bytes4, addr, []byte) error
 }
 
+// ErrNotAuthorized is wrapped by the error of every access-controlled method the caller isn't allowed to use
+var ErrNotAuthorized = errors.New("unauthorized caller to access-controlled method")
+
+var ErrNotOwner = fmt.Errorf("%w: must be called by chain owner", ErrNotAuthorized)
+
+// requireOwner ensures the caller is a chain owner, for methods of precompiles not wrapped by OwnerPrecompile
+func requireOwner(c ctx) error {
+	isOwner, err := c.State.ChainOwners().IsMember(c.caller)
+	if err != nil {
+		return err
+	}
+	if !isOwner {
+		return ErrNotOwner
+	}
+	return nil
+}
+
 func ownerOnly(address addr, impl ArbosPrecompile, emit func(mech, bytes4, addr, []byte) error) (addr, ArbosPrecompile) {
 	return address, &OwnerPrecompile{
 		precompile:  impl,
@@ -93,7 +111,7 @@ func (wrapper *OwnerPrecompile) Call(
 	}
 
 	if !isOwner {
-		return nil, burner.gasLeft, errors.New("unauthorized caller to access-controlled method")
+		return nil, burner.gasLeft, ErrNotOwner
 	}
 
 	output, _, err := con.Call(input, precompileAddress, actingAsAddress, caller, value, readOnly, gasSupplied, evm)